@@ -0,0 +1,39 @@
+package metanode
+
+import "context"
+
+// Common is the capability layer available to any authenticated caller:
+// version/auth introspection and read-only app status. Modeled on
+// Lotus's Common API, which FullNode/StorageMiner layer on top of.
+type Common interface {
+	Version(ctx context.Context) (string, error)
+	AuthVerify(ctx context.Context, token string) ([]string, error)
+	Status(ctx context.Context, appPath string) error
+}
+
+// Full layers app lifecycle and agreement operations on top of Common.
+type Full interface {
+	Common
+
+	InitAppCtx(ctx context.Context, appName string) error
+	DeployAppCtx(ctx context.Context, appPath string) error
+	CreateAgreementCtx(ctx context.Context, appPath, agreementType string) (*Agreement, error)
+	DeployAgreementCtx(ctx context.Context, appPath, agreementID string) error
+	TestTestnetConnectionCtx(ctx context.Context) error
+}
+
+// Cluster layers node-cluster creation, membership, and health on top
+// of Common.
+type Cluster interface {
+	Common
+
+	CreateNodeClusterCtx(ctx context.Context, appPath string) error
+	ClusterMembersCtx(ctx context.Context, appPath string) ([]string, error)
+	ClusterHealthCtx(ctx context.Context, appPath string) (map[string]string, error)
+}
+
+var (
+	_ Common  = (*MetaNodeSDK)(nil)
+	_ Full    = (*MetaNodeSDK)(nil)
+	_ Cluster = (*MetaNodeSDK)(nil)
+)
@@ -0,0 +1,28 @@
+// Package beacon provides verifiable public randomness for seeding
+// agreement IDs, initial validator sets, and cluster shard assignments,
+// in place of local crypto/rand. Beacon.Entry/Latest are the only
+// surface the SDK depends on; DrandHTTPBeacon implements them against a
+// drand HTTP relay, and MockBeacon is a deterministic in-memory stand-in
+// for tests.
+package beacon
+
+import "context"
+
+// BeaconEntry is one round of verifiable randomness.
+type BeaconEntry struct {
+	Round      uint64 `json:"round"`
+	Data       []byte `json:"randomness"`
+	Signature  []byte `json:"signature"`
+	PrevSigned []byte `json:"previous_signature,omitempty"`
+}
+
+// Beacon exposes unbiasable public randomness on a fixed cadence.
+// Implementations must verify Data/Signature before returning an entry;
+// the exception is MockBeacon, a test stand-in that returns its seeded
+// entries unverified.
+type Beacon interface {
+	// Entry returns the (verified) randomness for a specific round.
+	Entry(round uint64) (BeaconEntry, error)
+	// Latest returns the most recent (verified) round.
+	Latest(ctx context.Context) (BeaconEntry, error)
+}
@@ -0,0 +1,206 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/sign/bls"
+	bls12381 "github.com/drand/kyber-bls12381"
+)
+
+// DrandHTTPBeacon fetches randomness from a drand HTTP relay
+// (https://drand.love) and verifies each round's signature against a
+// distribution key pinned by the caller, so the randomness it returns
+// is unbiasable and independently auditable.
+type DrandHTTPBeacon struct {
+	RelayURL  string
+	ChainHash string
+	DistKey   kyber.Point
+	CacheSize int
+
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	cache  map[uint64]BeaconEntry
+	order  []uint64 // insertion order, for trimming cache to CacheSize
+	latest uint64
+}
+
+// NewDrandHTTPBeacon returns a Beacon backed by relayURL/chainHash,
+// verifying every round against distKeyHex (the chain's hex-encoded
+// BLS12-381 distribution public key).
+func NewDrandHTTPBeacon(relayURL, chainHash, distKeyHex string) (*DrandHTTPBeacon, error) {
+	raw, err := hex.DecodeString(distKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: invalid distribution key hex: %v", err)
+	}
+
+	distKey := bls12381.NewBLS12381Suite().G1().Point()
+	if err := distKey.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("beacon: invalid distribution key: %v", err)
+	}
+
+	return &DrandHTTPBeacon{
+		RelayURL:   strings.TrimRight(relayURL, "/"),
+		ChainHash:  chainHash,
+		DistKey:    distKey,
+		CacheSize:  128,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[uint64]BeaconEntry),
+	}, nil
+}
+
+type drandHTTPEntry struct {
+	Round             uint64 `json:"round"`
+	Randomness        string `json:"randomness"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature"`
+}
+
+func (b *DrandHTTPBeacon) Entry(round uint64) (BeaconEntry, error) {
+	if cached, ok := b.cached(round); ok {
+		return cached, nil
+	}
+
+	entry, err := b.fetch(context.Background(), fmt.Sprintf("%d", round))
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	if err := b.verify(entry); err != nil {
+		return BeaconEntry{}, err
+	}
+
+	b.store(entry)
+	return entry, nil
+}
+
+func (b *DrandHTTPBeacon) Latest(ctx context.Context) (BeaconEntry, error) {
+	entry, err := b.fetch(ctx, "latest")
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	if err := b.verify(entry); err != nil {
+		return BeaconEntry{}, err
+	}
+
+	b.store(entry)
+	return entry, nil
+}
+
+func (b *DrandHTTPBeacon) fetch(ctx context.Context, round string) (BeaconEntry, error) {
+	url := fmt.Sprintf("%s/%s/public/%s", b.RelayURL, b.ChainHash, round)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: building request: %v", err)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: fetching %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+
+	var raw drandHTTPEntry
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: decoding response: %v", err)
+	}
+
+	randomness, err := hex.DecodeString(raw.Randomness)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: invalid randomness hex: %v", err)
+	}
+	signature, err := hex.DecodeString(raw.Signature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: invalid signature hex: %v", err)
+	}
+	prevSig, err := hex.DecodeString(raw.PreviousSignature)
+	if err != nil {
+		return BeaconEntry{}, fmt.Errorf("beacon: invalid previous signature hex: %v", err)
+	}
+
+	return BeaconEntry{
+		Round:      raw.Round,
+		Data:       randomness,
+		Signature:  signature,
+		PrevSigned: prevSig,
+	}, nil
+}
+
+// verify checks entry.Signature against DistKey over the chained-mode
+// drand message, sha256(PrevSigned || Round), and that entry.Data is
+// sha256(Signature).
+func (b *DrandHTTPBeacon) verify(entry BeaconEntry) error {
+	msg := roundMessage(entry.PrevSigned, entry.Round)
+	// Chained-mode drand keys live on G1 and signatures on G2.
+	scheme := bls.NewSchemeOnG2(bls12381.NewBLS12381Suite())
+	if err := scheme.Verify(b.DistKey, msg, entry.Signature); err != nil {
+		return fmt.Errorf("beacon: signature verification failed for round %d: %v", entry.Round, err)
+	}
+	if sum := sha256.Sum256(entry.Signature); string(sum[:]) != string(entry.Data) {
+		return fmt.Errorf("beacon: randomness for round %d is not sha256 of its signature", entry.Round)
+	}
+	return nil
+}
+
+// roundMessage builds the message drand signs for a chained-mode round:
+// sha256(prevSig || round_bytes), round_bytes being round as a big-endian
+// uint64.
+func roundMessage(prevSig []byte, round uint64) []byte {
+	var roundBytes [8]byte
+	binary.BigEndian.PutUint64(roundBytes[:], round)
+
+	h := sha256.New()
+	h.Write(prevSig)
+	h.Write(roundBytes[:])
+	return h.Sum(nil)
+}
+
+func (b *DrandHTTPBeacon) cached(round uint64) (BeaconEntry, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.cache[round]
+	return entry, ok
+}
+
+func (b *DrandHTTPBeacon) store(entry BeaconEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.cache[entry.Round]; !exists {
+		b.order = append(b.order, entry.Round)
+		for len(b.order) > b.CacheSize {
+			delete(b.cache, b.order[0])
+			b.order = b.order[1:]
+		}
+	}
+	b.cache[entry.Round] = entry
+	if entry.Round > b.latest {
+		b.latest = entry.Round
+	}
+}
+
+// Watch polls for new rounds every period until ctx is cancelled,
+// verifying and caching each one so subsequent Entry calls for those
+// rounds are served from memory. Latest always queries the relay.
+func (b *DrandHTTPBeacon) Watch(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.Latest(ctx) //nolint:errcheck // best-effort refresh; next Latest call will retry
+		}
+	}
+}
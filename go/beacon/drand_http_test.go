@@ -0,0 +1,34 @@
+package beacon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+)
+
+func TestRoundMessageHashesWithSHA256(t *testing.T) {
+	prevSig := []byte{0x01, 0x02, 0x03}
+	round := uint64(42)
+
+	got := roundMessage(prevSig, round)
+
+	var roundBytes [8]byte
+	binary.BigEndian.PutUint64(roundBytes[:], round)
+	sum := sha256.Sum256(append(append([]byte{}, prevSig...), roundBytes[:]...))
+
+	if !bytes.Equal(got, sum[:]) {
+		t.Fatalf("roundMessage(%x, %d) = %x, want sha256(prevSig||round) = %x", prevSig, round, got, sum)
+	}
+}
+
+func TestRoundMessageDiffersByRound(t *testing.T) {
+	prevSig := []byte{0xaa, 0xbb}
+
+	a := roundMessage(prevSig, 1)
+	b := roundMessage(prevSig, 2)
+
+	if bytes.Equal(a, b) {
+		t.Fatal("roundMessage should differ between rounds sharing the same previous signature")
+	}
+}
@@ -0,0 +1,56 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MockBeacon is an in-memory Beacon for tests: rounds are seeded with
+// Set and never verified, so it requires no network access or pinned
+// distribution key.
+type MockBeacon struct {
+	mu      sync.Mutex
+	entries map[uint64]BeaconEntry
+	latest  uint64
+}
+
+// NewMockBeacon returns an empty MockBeacon; use Set to seed rounds.
+func NewMockBeacon() *MockBeacon {
+	return &MockBeacon{entries: make(map[uint64]BeaconEntry)}
+}
+
+// Set seeds round with entry, making it the latest round if it is the
+// highest seeded so far.
+func (b *MockBeacon) Set(round uint64, entry BeaconEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry.Round = round
+	b.entries[round] = entry
+	if round > b.latest {
+		b.latest = round
+	}
+}
+
+func (b *MockBeacon) Entry(round uint64) (BeaconEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.entries[round]
+	if !ok {
+		return BeaconEntry{}, fmt.Errorf("beacon: no mock entry seeded for round %d", round)
+	}
+	return entry, nil
+}
+
+func (b *MockBeacon) Latest(ctx context.Context) (BeaconEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.entries[b.latest]
+	if !ok {
+		return BeaconEntry{}, fmt.Errorf("beacon: no mock entries seeded")
+	}
+	return entry, nil
+}
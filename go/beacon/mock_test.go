@@ -0,0 +1,43 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMockBeaconEntryRequiresSeededRound(t *testing.T) {
+	b := NewMockBeacon()
+
+	if _, err := b.Entry(1); err == nil {
+		t.Fatal("expected Entry for an unseeded round to fail")
+	}
+
+	b.Set(1, BeaconEntry{Data: []byte("round-1")})
+	entry, err := b.Entry(1)
+	if err != nil {
+		t.Fatalf("Entry(1): %v", err)
+	}
+	if entry.Round != 1 || string(entry.Data) != "round-1" {
+		t.Fatalf("Entry(1) = %+v, want Round 1 with Data \"round-1\"", entry)
+	}
+}
+
+func TestMockBeaconLatestTracksHighestSeededRound(t *testing.T) {
+	b := NewMockBeacon()
+
+	if _, err := b.Latest(context.Background()); err == nil {
+		t.Fatal("expected Latest with no seeded rounds to fail")
+	}
+
+	b.Set(1, BeaconEntry{Data: []byte("round-1")})
+	b.Set(3, BeaconEntry{Data: []byte("round-3")})
+	b.Set(2, BeaconEntry{Data: []byte("round-2")})
+
+	latest, err := b.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if latest.Round != 3 {
+		t.Fatalf("Latest().Round = %d, want 3 (the highest seeded round)", latest.Round)
+	}
+}
@@ -0,0 +1,150 @@
+package metanode
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// CLITransport implements Transport by shelling out to the metanode-cli
+// binary and parsing its stdout, preserving the SDK's original behavior.
+type CLITransport struct {
+	CLIPath string
+}
+
+// NewCLITransport locates metanode-cli on disk and returns a Transport
+// backed by it.
+func NewCLITransport() (*CLITransport, error) {
+	cliPath, err := findCLI()
+	if err != nil {
+		return nil, err
+	}
+	return &CLITransport{CLIPath: cliPath}, nil
+}
+
+func (t *CLITransport) InitApp(ctx context.Context, appName, network, rpcEndpoint string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "init", appName, "--network", network, "--rpc", rpcEndpoint)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error initializing app: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func (t *CLITransport) DeployApp(ctx context.Context, appPath, network, rpcEndpoint string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "deploy", appPath, "--network", network, "--rpc", rpcEndpoint)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error deploying app: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func (t *CLITransport) CreateAgreement(ctx context.Context, appPath, agreementType string, seed []byte) (*Agreement, error) {
+	args := []string{"agreement", appPath, "--create", "--type", agreementType}
+	if len(seed) > 0 {
+		args = append(args, "--seed", hex.EncodeToString(seed))
+	}
+
+	cmd := exec.CommandContext(ctx, t.CLIPath, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("error creating agreement: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+
+	// Parse output to find agreement ID
+	lines := strings.Split(string(output), "\n")
+	var agreementID string
+	for _, line := range lines {
+		if strings.Contains(line, "Agreement created with ID:") {
+			parts := strings.Split(line, ":")
+			if len(parts) >= 2 {
+				agreementID = strings.TrimSpace(parts[1])
+			}
+		}
+	}
+
+	if agreementID == "" {
+		return nil, fmt.Errorf("could not find agreement ID in output")
+	}
+
+	return GetAgreement(appPath, agreementID)
+}
+
+func (t *CLITransport) DeployAgreement(ctx context.Context, appPath, agreementID string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "agreement", appPath, "--deploy", "--id", agreementID)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error deploying agreement: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func (t *CLITransport) CheckStatus(ctx context.Context, appPath string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "status", appPath)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error checking status: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func (t *CLITransport) TestTestnetConnection(ctx context.Context, rpcEndpoint string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "testnet", "--test", "--rpc", rpcEndpoint)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error testing testnet connection: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+func (t *CLITransport) CreateNodeCluster(ctx context.Context, appPath, rpcEndpoint string) error {
+	cmd := exec.CommandContext(ctx, t.CLIPath, "cluster", appPath, "--create", "--rpc", rpcEndpoint)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error creating node cluster: %v, output: %s", err, string(output))
+	}
+
+	fmt.Println(string(output))
+	return nil
+}
+
+// Helper function to find the CLI path
+func findCLI() (string, error) {
+	// Check if CLI is in PATH
+	path, err := exec.LookPath("metanode-cli")
+	if err == nil {
+		return path, nil
+	}
+
+	// Check in common locations
+	locations := []string{
+		filepath.Join(os.Getenv("HOME"), "bin", "metanode-cli"),
+		filepath.Join(os.Getenv("HOME"), "metanode-deployment", "bin", "metanode-cli"),
+		"/usr/local/bin/metanode-cli",
+	}
+
+	for _, loc := range locations {
+		if _, err := os.Stat(loc); err == nil {
+			return loc, nil
+		}
+	}
+
+	return "", fmt.Errorf("metanode-cli not found")
+}
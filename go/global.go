@@ -0,0 +1,141 @@
+package metanode
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/GlobalSushrut/metanode-sdk/go/beacon"
+)
+
+var (
+	// initMu serializes InitMetanode calls so two concurrent callers
+	// can't both observe globalContainer as nil and both build a
+	// Container; it is held for the full duration of a build attempt.
+	initMu sync.Mutex
+
+	globalMu        sync.RWMutex
+	globalContainer *Container
+)
+
+// Container holds a process-wide, fully-initialized SDK plus state
+// derived from its Config, so code deep in a dependency graph can reach
+// it via Client()/GlobalConfig() without an *MetaNodeSDK threaded
+// through every function call.
+type Container struct {
+	SDK    *MetaNodeSDK
+	Config Config
+
+	// ConsensusThreshold is the minimum number of nodes that must submit
+	// for a write to be considered final:
+	// max(1, ceil(MinSubmit * len(Nodes) / 100)).
+	ConsensusThreshold int
+}
+
+// InitMetanode builds the process-wide Container from cfg and opts. It
+// may only succeed once per process; later calls return an error. A
+// failed call (buildAndSetContainer returning an error) does not count
+// as having initialized the container, so a transient failure can be
+// retried by calling InitMetanode again. Long-running daemons that need
+// to rotate endpoints after a successful call should use Reconfigure
+// instead of calling InitMetanode again.
+func InitMetanode(cfg Config, opts ...Option) error {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	globalMu.RLock()
+	ready := globalContainer != nil
+	globalMu.RUnlock()
+	if ready {
+		return fmt.Errorf("metanode: InitMetanode already called")
+	}
+
+	return buildAndSetContainer(cfg, opts...)
+}
+
+// Reconfigure atomically swaps the process-wide Container for one built
+// from cfg, letting a long-running daemon rotate endpoints or nodes
+// without restarting. InitMetanode must have been called first.
+func Reconfigure(cfg Config, opts ...Option) error {
+	globalMu.RLock()
+	ready := globalContainer != nil
+	globalMu.RUnlock()
+	if !ready {
+		return fmt.Errorf("metanode: InitMetanode must be called before Reconfigure")
+	}
+	return buildAndSetContainer(cfg, opts...)
+}
+
+func buildAndSetContainer(cfg Config, opts ...Option) error {
+	built := []Option{}
+	if cfg.Network != "" {
+		built = append(built, WithNetwork(cfg.Network))
+	}
+	if cfg.RPCEndpoint != "" {
+		built = append(built, WithRPC(cfg.RPCEndpoint, cfg.WSEndpoint))
+	}
+	if cfg.DrandRelayURL != "" && cfg.DrandChainHash != "" && cfg.DrandDistKeyHex != "" {
+		b, err := beacon.NewDrandHTTPBeacon(cfg.DrandRelayURL, cfg.DrandChainHash, cfg.DrandDistKeyHex)
+		if err != nil {
+			return fmt.Errorf("metanode: configuring drand beacon: %v", err)
+		}
+		built = append(built, WithBeacon(b))
+	}
+	built = append(built, opts...)
+
+	sdk, err := NewSDK(built...)
+	if err != nil {
+		return fmt.Errorf("metanode: building SDK: %v", err)
+	}
+
+	c := &Container{
+		SDK:                sdk,
+		Config:             cfg,
+		ConsensusThreshold: consensusThreshold(cfg),
+	}
+
+	globalMu.Lock()
+	globalContainer = c
+	globalMu.Unlock()
+	return nil
+}
+
+// consensusThreshold computes the minimum number of nodes that must
+// submit for consensus: at least one node, and at least MinSubmit
+// percent of len(cfg.Nodes).
+func consensusThreshold(cfg Config) int {
+	if len(cfg.Nodes) == 0 {
+		return 1
+	}
+	required := math.Ceil(float64(cfg.MinSubmit) * float64(len(cfg.Nodes)) / 100)
+	return maxInt(1, int(required))
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// GlobalContainer returns the process-wide Container set up by
+// InitMetanode. It panics if InitMetanode has not been called yet.
+func GlobalContainer() *Container {
+	globalMu.RLock()
+	defer globalMu.RUnlock()
+	if globalContainer == nil {
+		panic("metanode: InitMetanode must be called before GlobalContainer")
+	}
+	return globalContainer
+}
+
+// GlobalConfig returns the Config the process-wide container was last
+// configured with.
+func GlobalConfig() Config {
+	return GlobalContainer().Config
+}
+
+// Client returns the process-wide *MetaNodeSDK set up by InitMetanode.
+func Client() *MetaNodeSDK {
+	return GlobalContainer().SDK
+}
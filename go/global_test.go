@@ -0,0 +1,49 @@
+package metanode
+
+import "testing"
+
+// stubTransport satisfies Transport via embedding without implementing
+// any method; these tests never call one, they only need a non-nil
+// Transport so NewSDK's "no transport configured" check passes.
+type stubTransport struct{ Transport }
+
+func resetGlobalForTest(t *testing.T) {
+	t.Helper()
+	globalMu.Lock()
+	globalContainer = nil
+	globalMu.Unlock()
+}
+
+func TestInitMetanodeRetriesAfterFailedCall(t *testing.T) {
+	resetGlobalForTest(t)
+	defer resetGlobalForTest(t)
+
+	if err := InitMetanode(Config{}); err == nil {
+		t.Fatal("expected InitMetanode with no transport configured to fail")
+	}
+
+	if err := InitMetanode(Config{}, WithTransport(stubTransport{})); err != nil {
+		t.Fatalf("InitMetanode should succeed on retry after a failed first call: %v", err)
+	}
+
+	if err := InitMetanode(Config{}, WithTransport(stubTransport{})); err == nil {
+		t.Fatal("expected a second InitMetanode call after success to be rejected")
+	}
+}
+
+func TestReconfigureRequiresPriorInitMetanode(t *testing.T) {
+	resetGlobalForTest(t)
+	defer resetGlobalForTest(t)
+
+	if err := Reconfigure(Config{}, WithTransport(stubTransport{})); err == nil {
+		t.Fatal("expected Reconfigure before any successful InitMetanode to fail")
+	}
+
+	if err := InitMetanode(Config{}, WithTransport(stubTransport{})); err != nil {
+		t.Fatalf("InitMetanode: %v", err)
+	}
+
+	if err := Reconfigure(Config{}, WithTransport(stubTransport{})); err != nil {
+		t.Fatalf("Reconfigure after a successful InitMetanode should succeed: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+// Package rpcpool maintains health-scored RPC endpoints for
+// metanode.RPCTransport: it picks the best-scoring healthy endpoint for
+// each call, retries on the next-best endpoint when a call fails, and
+// runs a background pinger to detect endpoints that have stalled.
+package rpcpool
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// FailoverPolicy controls how a Pool scores endpoints and retries calls.
+type FailoverPolicy struct {
+	// MaxRetries is how many additional endpoints to try after a call
+	// fails on the first one.
+	MaxRetries int
+	// MaxConsecutiveFailures marks an endpoint unhealthy once its
+	// failure streak reaches this value.
+	MaxConsecutiveFailures int
+	// MaxHeightLag marks an endpoint unhealthy once its last reported
+	// block height falls more than this many blocks behind the pool's
+	// max observed height.
+	MaxHeightLag uint64
+	// EWMAAlpha weights the latest latency sample in the rolling
+	// average (0 < alpha <= 1; higher reacts faster to recent calls).
+	EWMAAlpha float64
+}
+
+// DefaultFailoverPolicy is used by New when policy is the zero value.
+var DefaultFailoverPolicy = FailoverPolicy{
+	MaxRetries:             2,
+	MaxConsecutiveFailures: 3,
+	MaxHeightLag:           5,
+	EWMAAlpha:              0.3,
+}
+
+// Stats is a point-in-time health snapshot of one endpoint.
+type Stats struct {
+	URL                 string
+	LatencyEWMA         time.Duration
+	ConsecutiveFailures int
+	LastHeight          uint64
+	Healthy             bool
+}
+
+type endpoint struct {
+	url string
+
+	mu                  sync.Mutex
+	latencyEWMA         time.Duration
+	consecutiveFailures int
+	lastHeight          uint64
+	lastSeen            time.Time
+}
+
+// Pool tracks a set of RPC endpoints and picks the healthiest one for
+// each call.
+type Pool struct {
+	policy FailoverPolicy
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+}
+
+// New creates a Pool over urls scored according to policy
+// (DefaultFailoverPolicy if the zero value).
+func New(urls []string, policy FailoverPolicy) *Pool {
+	if policy == (FailoverPolicy{}) {
+		policy = DefaultFailoverPolicy
+	}
+
+	endpoints := make([]*endpoint, len(urls))
+	for i, u := range urls {
+		endpoints[i] = &endpoint{url: u}
+	}
+
+	return &Pool{policy: policy, endpoints: endpoints}
+}
+
+// Do calls fn with the highest-scored healthy endpoint, retrying on the
+// next-best endpoint (up to policy.MaxRetries additional attempts) if fn
+// returns an error.
+func (p *Pool) Do(ctx context.Context, fn func(ctx context.Context, url string) error) error {
+	tried := make(map[string]bool, len(p.endpoints))
+	var lastErr error
+
+	for attempt := 0; attempt <= p.policy.MaxRetries; attempt++ {
+		ep := p.pick(tried)
+		if ep == nil {
+			if lastErr != nil {
+				return lastErr
+			}
+			return errNoHealthyEndpoint
+		}
+		tried[ep.url] = true
+
+		start := time.Now()
+		err := fn(ctx, ep.url)
+		p.recordCall(ep, time.Since(start), err)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+func (p *Pool) pick(exclude map[string]bool) *endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	maxHeight := p.maxHeightLocked()
+
+	var best *endpoint
+	bestScore := math.Inf(-1)
+	for _, ep := range p.endpoints {
+		if exclude[ep.url] || !p.healthyLocked(ep, maxHeight) {
+			continue
+		}
+		if score := p.scoreLocked(ep); score > bestScore {
+			best, bestScore = ep, score
+		}
+	}
+	return best
+}
+
+func (p *Pool) maxHeightLocked() uint64 {
+	var max uint64
+	for _, ep := range p.endpoints {
+		ep.mu.Lock()
+		if ep.lastHeight > max {
+			max = ep.lastHeight
+		}
+		ep.mu.Unlock()
+	}
+	return max
+}
+
+func (p *Pool) healthyLocked(ep *endpoint, maxHeight uint64) bool {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.consecutiveFailures >= p.policy.MaxConsecutiveFailures {
+		return false
+	}
+	if maxHeight > 0 && maxHeight-ep.lastHeight > p.policy.MaxHeightLag {
+		return false
+	}
+	return true
+}
+
+// scoreLocked ranks endpoints by lowest latency and failure streak;
+// higher is better.
+func (p *Pool) scoreLocked(ep *endpoint) float64 {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	return -float64(ep.latencyEWMA) - float64(ep.consecutiveFailures)*float64(time.Second)
+}
+
+func (p *Pool) recordCall(ep *endpoint, latency time.Duration, err error) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if ep.latencyEWMA == 0 {
+		ep.latencyEWMA = latency
+	} else {
+		alpha := p.policy.EWMAAlpha
+		ep.latencyEWMA = time.Duration(alpha*float64(latency) + (1-alpha)*float64(ep.latencyEWMA))
+	}
+
+	if err != nil {
+		ep.consecutiveFailures++
+		return
+	}
+	ep.consecutiveFailures = 0
+	ep.lastSeen = time.Now()
+}
+
+// Watch periodically pings every endpoint until ctx is cancelled,
+// updating each endpoint's last-known height and failure streak. ping
+// performs a lightweight liveness call (the equivalent of
+// eth_blockNumber) and returns the endpoint's current block height.
+func (p *Pool) Watch(ctx context.Context, interval time.Duration, ping func(ctx context.Context, url string) (uint64, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.pingAll(ctx, ping)
+		}
+	}
+}
+
+func (p *Pool) pingAll(ctx context.Context, ping func(ctx context.Context, url string) (uint64, error)) {
+	p.mu.RLock()
+	endpoints := make([]*endpoint, len(p.endpoints))
+	copy(endpoints, p.endpoints)
+	p.mu.RUnlock()
+
+	for _, ep := range endpoints {
+		height, err := ping(ctx, ep.url)
+
+		ep.mu.Lock()
+		if err != nil {
+			ep.consecutiveFailures++
+		} else {
+			ep.consecutiveFailures = 0
+			ep.lastHeight = height
+			ep.lastSeen = time.Now()
+		}
+		ep.mu.Unlock()
+	}
+}
+
+// Stats returns a snapshot of every tracked endpoint's health.
+func (p *Pool) Stats() []Stats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	maxHeight := p.maxHeightLocked()
+	stats := make([]Stats, len(p.endpoints))
+	for i, ep := range p.endpoints {
+		ep.mu.Lock()
+		stats[i] = Stats{
+			URL:                 ep.url,
+			LatencyEWMA:         ep.latencyEWMA,
+			ConsecutiveFailures: ep.consecutiveFailures,
+			LastHeight:          ep.lastHeight,
+			Healthy:             p.healthyLockedUnsafe(ep, maxHeight),
+		}
+		ep.mu.Unlock()
+	}
+	return stats
+}
+
+// healthyLockedUnsafe is healthyLocked without re-acquiring ep.mu, for
+// callers (Stats) that already hold it.
+func (p *Pool) healthyLockedUnsafe(ep *endpoint, maxHeight uint64) bool {
+	if ep.consecutiveFailures >= p.policy.MaxConsecutiveFailures {
+		return false
+	}
+	if maxHeight > 0 && maxHeight-ep.lastHeight > p.policy.MaxHeightLag {
+		return false
+	}
+	return true
+}
+
+type poolError string
+
+func (e poolError) Error() string { return string(e) }
+
+const errNoHealthyEndpoint = poolError("rpcpool: no healthy endpoint available")
@@ -0,0 +1,93 @@
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPoolDoFailsOverToNextEndpoint(t *testing.T) {
+	p := New([]string{"http://a", "http://b"}, FailoverPolicy{
+		MaxRetries:             1,
+		MaxConsecutiveFailures: 3,
+		MaxHeightLag:           5,
+		EWMAAlpha:              0.3,
+	})
+
+	var called []string
+	err := p.Do(context.Background(), func(ctx context.Context, url string) error {
+		called = append(called, url)
+		if url == "http://a" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if len(called) != 2 {
+		t.Fatalf("Do called endpoints %v, want exactly 2 attempts", called)
+	}
+}
+
+func TestPoolDoReturnsLastErrorWhenAllEndpointsFail(t *testing.T) {
+	p := New([]string{"http://a", "http://b"}, FailoverPolicy{
+		MaxRetries:             1,
+		MaxConsecutiveFailures: 3,
+		MaxHeightLag:           5,
+		EWMAAlpha:              0.3,
+	})
+
+	err := p.Do(context.Background(), func(ctx context.Context, url string) error {
+		return errors.New("boom: " + url)
+	})
+	if err == nil {
+		t.Fatal("expected Do to return an error when every endpoint fails")
+	}
+}
+
+func TestPoolMarksEndpointUnhealthyAfterConsecutiveFailures(t *testing.T) {
+	// A single-endpoint pool so every Do call is forced through
+	// http://a: with more than one endpoint, a pool routes around a
+	// failing one toward its healthier sibling and its failure streak
+	// never advances past one.
+	p := New([]string{"http://a"}, FailoverPolicy{
+		MaxRetries:             0,
+		MaxConsecutiveFailures: 2,
+		MaxHeightLag:           5,
+		EWMAAlpha:              0.3,
+	})
+
+	for i := 0; i < 2; i++ {
+		p.Do(context.Background(), func(ctx context.Context, url string) error {
+			return errors.New("boom")
+		})
+	}
+
+	for _, s := range p.Stats() {
+		if s.URL == "http://a" && s.Healthy {
+			t.Fatalf("endpoint %s should be unhealthy after %d consecutive failures, got Stats %+v", s.URL, s.ConsecutiveFailures, s)
+		}
+	}
+}
+
+func TestPoolDoReturnsErrNoHealthyEndpointWhenAllUnhealthy(t *testing.T) {
+	p := New([]string{"http://a"}, FailoverPolicy{
+		MaxRetries:             0,
+		MaxConsecutiveFailures: 1,
+		MaxHeightLag:           5,
+		EWMAAlpha:              0.3,
+	})
+
+	p.Do(context.Background(), func(ctx context.Context, url string) error {
+		return errors.New("boom")
+	})
+
+	err := p.Do(context.Background(), func(ctx context.Context, url string) error {
+		t.Fatal("fn should not be called: the only endpoint is unhealthy")
+		return nil
+	})
+	if !errors.Is(err, errNoHealthyEndpoint) {
+		t.Fatalf("Do() error = %v, want errNoHealthyEndpoint", err)
+	}
+}
@@ -1,29 +1,66 @@
 package metanode
 
 import (
+	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
-	"strings"
+	"time"
+
+	"github.com/GlobalSushrut/metanode-sdk/go/beacon"
+	"github.com/GlobalSushrut/metanode-sdk/go/internal/rpcpool"
 )
 
+// rpcPoolWatchInterval is how often NewSDK's background watcher pings
+// every endpoint in a WithRPCEndpoints pool to refresh its health/height.
+const rpcPoolWatchInterval = 30 * time.Second
+
 // SDK version
 const Version = "1.1.0"
 
 // MetaNodeSDK represents the main SDK interface
 type MetaNodeSDK struct {
-	CLIPath      string
-	Network      string
-	RPCEndpoint  string
-	WSEndpoint   string
-	ConfigPath   string
-	WalletPath   string
-	IpfsGateway  string
-	initialized  bool
+	CLIPath     string
+	Network     string
+	RPCEndpoint string
+	WSEndpoint  string
+	ConfigPath  string
+	WalletPath  string
+	IpfsGateway string
+	initialized bool
+
+	// Transport is the underlying client used to reach a MetaNode node.
+	// Defaults to a CLITransport; WithRPC/WithTransport opt into a
+	// native JSON-RPC/WS client instead.
+	Transport Transport
+
+	// Pluggable subsystems, configurable via functional options. Each
+	// has a working default so callers only need to set what they want
+	// to replace.
+	Wallet         Wallet
+	KeyStore       KeyStore
+	AgreementStore AgreementStore
+	ClusterManager ClusterManager
+	Logger         Logger
+	Metrics        MetricsSink
+	Authenticator  Authenticator
+
+	// Beacon, when set, seeds CreateAgreement's ID / initial validator
+	// set / cluster shard assignment from verifiable public randomness
+	// instead of crypto/rand. See the metanode/beacon package.
+	Beacon beacon.Beacon
+
+	// RPCPool holds the health-scored endpoint pool backing Transport
+	// when WithRPCEndpoints was used; nil for a single-endpoint
+	// RPCTransport or a CLITransport. Use RPCStats for diagnostics.
+	RPCPool *rpcpool.Pool
+
+	rpcPoolEndpoints []string
+	rpcPoolPolicy    rpcpool.FailoverPolicy
+	poolWatchCancel  context.CancelFunc
 }
 
 // Config represents the SDK configuration
@@ -38,6 +75,21 @@ type Config struct {
 	IpfsGateway      string            `json:"ipfs_gateway"`
 	WalletPath       string            `json:"wallet_path"`
 	Testnet          map[string]string `json:"testnet"`
+
+	// Nodes and MinSubmit drive the consensus submission threshold
+	// computed by InitMetanode/Reconfigure: at least MinSubmit percent
+	// of Nodes must submit before a write is considered final.
+	Nodes     []string `json:"nodes"`
+	MinSubmit int      `json:"min_submit"`
+
+	// DrandRelayURL, DrandChainHash, and DrandDistKeyHex pin the
+	// verifiable randomness beacon InitMetanode/Reconfigure wire up for
+	// CreateAgreementCtx to seed from, in place of local crypto/rand. All
+	// three must be set for the beacon to be configured; see
+	// beacon.NewDrandHTTPBeacon.
+	DrandRelayURL   string `json:"drand_relay_url"`
+	DrandChainHash  string `json:"drand_chain_hash"`
+	DrandDistKeyHex string `json:"drand_dist_key_hex"`
 }
 
 // Agreement represents a blockchain agreement
@@ -54,34 +106,105 @@ type Agreement struct {
 	Meta           map[string]string `json:"meta"`
 }
 
-// NewSDK creates a new MetaNode SDK instance
-func NewSDK() (*MetaNodeSDK, error) {
-	// Find the CLI path
-	cliPath, err := findCLI()
-	if err != nil {
-		return nil, err
+// NewSDK creates a new MetaNode SDK instance. It defaults to a
+// CLITransport backed by metanode-cli and an on-disk AgreementStore;
+// pass Options such as WithRPC, WithWallet, or WithAgreementStore to
+// swap in alternative subsystems without touching SDK code.
+func NewSDK(opts ...Option) (*MetaNodeSDK, error) {
+	sdk := &MetaNodeSDK{
+		Network:        "testnet",
+		RPCEndpoint:    "http://159.203.17.36:8545",
+		WSEndpoint:     "ws://159.203.17.36:8546",
+		ConfigPath:     filepath.Join(os.Getenv("HOME"), ".metanode"),
+		WalletPath:     filepath.Join(os.Getenv("HOME"), ".metanode", "wallet"),
+		IpfsGateway:    "http://localhost:8081",
+		AgreementStore: fileAgreementStore{},
+		Logger:         noopLogger{},
+		Metrics:        noopMetricsSink{},
+		Authenticator:  denyAllAuthenticator{},
 	}
+	sdk.KeyStore = fileKeyStore{dir: sdk.WalletPath}
 
-	// Create SDK instance with defaults
-	sdk := &MetaNodeSDK{
-		CLIPath:     cliPath,
-		Network:     "testnet",
-		RPCEndpoint: "http://159.203.17.36:8545",
-		WSEndpoint:  "ws://159.203.17.36:8546",
-		ConfigPath:  filepath.Join(os.Getenv("HOME"), ".metanode"),
-		WalletPath:  filepath.Join(os.Getenv("HOME"), ".metanode", "wallet"),
-		IpfsGateway: "http://localhost:8081",
+	// Best-effort CLITransport default; an explicit WithRPC/WithTransport
+	// option below may replace it.
+	if cli, err := NewCLITransport(); err == nil {
+		sdk.CLIPath = cli.CLIPath
+		sdk.Transport = cli
+	}
+
+	for _, opt := range opts {
+		opt(sdk)
+	}
+
+	// Built from the final KeyStore (after options have applied) so
+	// WithKeyStore takes effect even when set after the default Wallet
+	// would otherwise have captured it. WithWallet always wins.
+	if sdk.Wallet == nil {
+		sdk.Wallet = keystoreWallet{ks: sdk.KeyStore, name: "default"}
+	}
+
+	if len(sdk.rpcPoolEndpoints) > 0 {
+		// WithRPCEndpoints takes precedence over WithRPC/WithTransport
+		// regardless of option order, so RPCEndpoint (still passed as a
+		// parameter by InitAppCtx/DeployAppCtx/TestTestnetConnectionCtx/
+		// CreateNodeClusterCtx, even though the calls themselves go
+		// through the pool) must be refreshed here too, not left at its
+		// single-endpoint default.
+		sdk.RPCEndpoint = sdk.rpcPoolEndpoints[0]
+		sdk.RPCPool = rpcpool.New(sdk.rpcPoolEndpoints, sdk.rpcPoolPolicy)
+		sdk.Transport = NewRPCTransportPool(sdk.RPCPool)
+	}
+
+	if sdk.Transport == nil {
+		return nil, fmt.Errorf("no transport configured: metanode-cli not found on PATH and no WithRPC/WithRPCEndpoints/WithTransport option given")
+	}
+
+	if rt, ok := sdk.Transport.(*RPCTransport); ok {
+		rt.Logger = sdk.Logger
+
+		if sdk.RPCPool != nil {
+			watchCtx, cancel := context.WithCancel(context.Background())
+			sdk.poolWatchCancel = cancel
+			go sdk.RPCPool.Watch(watchCtx, rpcPoolWatchInterval, rt.Ping)
+		}
 	}
 
 	return sdk, nil
 }
 
+// RPCStats returns per-endpoint health metrics when the SDK was built
+// with WithRPCEndpoints, or nil otherwise.
+func (sdk *MetaNodeSDK) RPCStats() []rpcpool.Stats {
+	if sdk.RPCPool == nil {
+		return nil
+	}
+	return sdk.RPCPool.Stats()
+}
+
+// Close stops the background RPCPool watcher started for a
+// WithRPCEndpoints SDK. It is a no-op for an SDK built without
+// WithRPCEndpoints. Callers that build an SDK with WithRPCEndpoints
+// should defer Close to avoid leaking the watcher goroutine.
+func (sdk *MetaNodeSDK) Close() {
+	if sdk.poolWatchCancel != nil {
+		sdk.poolWatchCancel()
+	}
+}
+
+// NewRPCSDK creates a MetaNode SDK instance that speaks JSON-RPC directly
+// to rpcEndpoint/wsEndpoint instead of shelling out to metanode-cli. It
+// is a convenience wrapper around NewSDK(WithRPC(...)).
+func NewRPCSDK(rpcEndpoint, wsEndpoint string) (*MetaNodeSDK, error) {
+	return NewSDK(WithRPC(rpcEndpoint, wsEndpoint))
+}
+
 // Initialize initializes the SDK
 func (sdk *MetaNodeSDK) Initialize() error {
-	// Check if CLI exists
-	_, err := os.Stat(sdk.CLIPath)
-	if os.IsNotExist(err) {
-		return fmt.Errorf("MetaNode CLI not found at %s", sdk.CLIPath)
+	// Check if CLI exists (not applicable to RPC-only SDKs)
+	if sdk.CLIPath != "" {
+		if _, err := os.Stat(sdk.CLIPath); os.IsNotExist(err) {
+			return fmt.Errorf("MetaNode CLI not found at %s", sdk.CLIPath)
+		}
 	}
 
 	// Create config directory if it doesn't exist
@@ -106,60 +229,108 @@ func (sdk *MetaNodeSDK) Initialize() error {
 
 // InitApp initializes a new MetaNode application
 func (sdk *MetaNodeSDK) InitApp(appName string) error {
-	cmd := exec.Command(sdk.CLIPath, "init", appName, "--network", sdk.Network, "--rpc", sdk.RPCEndpoint)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error initializing app: %v, output: %s", err, string(output))
-	}
+	return sdk.InitAppCtx(context.Background(), appName)
+}
 
-	fmt.Println(string(output))
-	return nil
+// InitAppCtx is the context-aware variant of InitApp.
+func (sdk *MetaNodeSDK) InitAppCtx(ctx context.Context, appName string) error {
+	return sdk.Transport.InitApp(ctx, appName, sdk.Network, sdk.RPCEndpoint)
 }
 
 // DeployApp deploys a MetaNode application
 func (sdk *MetaNodeSDK) DeployApp(appPath string) error {
-	cmd := exec.Command(sdk.CLIPath, "deploy", appPath, "--network", sdk.Network, "--rpc", sdk.RPCEndpoint)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error deploying app: %v, output: %s", err, string(output))
-	}
+	return sdk.DeployAppCtx(context.Background(), appPath)
+}
 
-	fmt.Println(string(output))
-	return nil
+// DeployAppCtx is the context-aware variant of DeployApp.
+func (sdk *MetaNodeSDK) DeployAppCtx(ctx context.Context, appPath string) error {
+	return sdk.Transport.DeployApp(ctx, appPath, sdk.Network, sdk.RPCEndpoint)
 }
 
 // CreateAgreement creates a new agreement for an application
 func (sdk *MetaNodeSDK) CreateAgreement(appPath string, agreementType string) (*Agreement, error) {
-	cmd := exec.Command(sdk.CLIPath, "agreement", appPath, "--create", "--type", agreementType)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, fmt.Errorf("error creating agreement: %v, output: %s", err, string(output))
+	return sdk.CreateAgreementCtx(context.Background(), appPath, agreementType)
+}
+
+// CreateAgreementCtx is the context-aware variant of CreateAgreement. If
+// sdk.Beacon is set, it seeds the agreement from the beacon's latest
+// round; see CreateAgreementAtRound to pin a specific round.
+func (sdk *MetaNodeSDK) CreateAgreementCtx(ctx context.Context, appPath, agreementType string) (*Agreement, error) {
+	return sdk.CreateAgreementAtRound(ctx, appPath, agreementType, 0)
+}
+
+// CreateAgreementAtRound is like CreateAgreement but seeds the
+// agreement's ID / initial validator set / cluster shard assignment
+// from sdk.Beacon's randomness at round (or the beacon's latest round
+// if round is 0) instead of local crypto/rand, making the assignment
+// verifiable and auditable. It falls back to the transport's own
+// randomness when sdk.Beacon is nil. If sdk.Wallet is set (it is, by
+// default), the agreement is signed and the signer/signature recorded
+// in its Meta.
+func (sdk *MetaNodeSDK) CreateAgreementAtRound(ctx context.Context, appPath, agreementType string, round uint64) (*Agreement, error) {
+	var seed []byte
+	if sdk.Beacon != nil {
+		entry, err := sdk.beaconEntry(ctx, round)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching beacon entry: %v", err)
+		}
+		seed = entry.Data
 	}
 
-	fmt.Println(string(output))
+	agreement, err := sdk.Transport.CreateAgreement(ctx, appPath, agreementType, seed)
+	if err != nil {
+		return nil, err
+	}
 
-	// Parse output to find agreement ID
-	lines := strings.Split(string(output), "\n")
-	var agreementID string
-	for _, line := range lines {
-		if strings.Contains(line, "Agreement created with ID:") {
-			parts := strings.Split(line, ":")
-			if len(parts) >= 2 {
-				agreementID = strings.TrimSpace(parts[1])
-			}
+	if sdk.Wallet != nil {
+		if err := sdk.signAgreement(agreement); err != nil {
+			return nil, fmt.Errorf("error signing agreement: %v", err)
 		}
 	}
 
-	if agreementID == "" {
-		return nil, fmt.Errorf("could not find agreement ID in output")
+	return agreement, nil
+}
+
+// signAgreement signs agreement's ID and type with sdk.Wallet, recording
+// the signer address and hex-encoded signature in agreement.Meta.
+func (sdk *MetaNodeSDK) signAgreement(agreement *Agreement) error {
+	sig, err := sdk.Wallet.Sign([]byte(agreement.ID + agreement.Type))
+	if err != nil {
+		return err
+	}
+
+	if agreement.Meta == nil {
+		agreement.Meta = make(map[string]string)
 	}
+	agreement.Meta["signer"] = sdk.Wallet.Address()
+	agreement.Meta["signature"] = hex.EncodeToString(sig)
 
-	// Get agreement details
-	return sdk.GetAgreement(appPath, agreementID)
+	sdk.Logger.Printf("metanode: signed agreement %s by %s", agreement.ID, agreement.Meta["signer"])
+	return nil
 }
 
-// GetAgreement gets details of an agreement
+func (sdk *MetaNodeSDK) beaconEntry(ctx context.Context, round uint64) (beacon.BeaconEntry, error) {
+	if round == 0 {
+		return sdk.Beacon.Latest(ctx)
+	}
+	return sdk.Beacon.Entry(round)
+}
+
+// GetAgreement gets details of an agreement via sdk.AgreementStore
+// (an on-disk JSON store by default).
 func (sdk *MetaNodeSDK) GetAgreement(appPath, agreementID string) (*Agreement, error) {
+	return sdk.AgreementStore.Load(appPath, agreementID)
+}
+
+// SaveAgreement persists an agreement via sdk.AgreementStore.
+func (sdk *MetaNodeSDK) SaveAgreement(appPath string, agreement *Agreement) error {
+	return sdk.AgreementStore.Save(appPath, agreement)
+}
+
+// GetAgreement reads an agreement's on-disk JSON record. It is a free
+// function (rather than an SDK method) because CLITransport needs it
+// before an *MetaNodeSDK* even exists.
+func GetAgreement(appPath, agreementID string) (*Agreement, error) {
 	// Agreement file path
 	agreementPath := filepath.Join(appPath, "metanode_agreements", fmt.Sprintf("agreement_%s.json", agreementID))
 
@@ -179,74 +350,106 @@ func (sdk *MetaNodeSDK) GetAgreement(appPath, agreementID string) (*Agreement, e
 	return &agreement, nil
 }
 
-// DeployAgreement deploys an agreement to the blockchain
-func (sdk *MetaNodeSDK) DeployAgreement(appPath, agreementID string) error {
-	cmd := exec.Command(sdk.CLIPath, "agreement", appPath, "--deploy", "--id", agreementID)
-	output, err := cmd.CombinedOutput()
+// SaveAgreement writes an agreement's JSON record to its on-disk
+// location under appPath.
+func SaveAgreement(appPath string, agreement *Agreement) error {
+	agreementPath := filepath.Join(appPath, "metanode_agreements", fmt.Sprintf("agreement_%s.json", agreement.ID))
+
+	data, err := json.MarshalIndent(agreement, "", "  ")
 	if err != nil {
-		return fmt.Errorf("error deploying agreement: %v, output: %s", err, string(output))
+		return fmt.Errorf("error encoding agreement JSON: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(agreementPath), 0755); err != nil {
+		return fmt.Errorf("error creating agreements directory: %v", err)
+	}
+
+	if err := ioutil.WriteFile(agreementPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing agreement file: %v", err)
 	}
 
-	fmt.Println(string(output))
 	return nil
 }
 
+// DeployAgreement deploys an agreement to the blockchain
+func (sdk *MetaNodeSDK) DeployAgreement(appPath, agreementID string) error {
+	return sdk.DeployAgreementCtx(context.Background(), appPath, agreementID)
+}
+
+// DeployAgreementCtx is the context-aware variant of DeployAgreement.
+func (sdk *MetaNodeSDK) DeployAgreementCtx(ctx context.Context, appPath, agreementID string) error {
+	return sdk.Transport.DeployAgreement(ctx, appPath, agreementID)
+}
+
 // CreateNodeCluster creates a node cluster for improved decentralization
 func (sdk *MetaNodeSDK) CreateNodeCluster(appPath string) error {
-	cmd := exec.Command(sdk.CLIPath, "cluster", appPath, "--create", "--rpc", sdk.RPCEndpoint)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error creating node cluster: %v, output: %s", err, string(output))
+	return sdk.CreateNodeClusterCtx(context.Background(), appPath)
+}
+
+// CreateNodeClusterCtx is the context-aware variant of CreateNodeCluster.
+// If sdk.ClusterManager is set it is used instead of Transport, so
+// cluster membership/health stay consistent with whichever backing
+// store WithClusterManager configured.
+func (sdk *MetaNodeSDK) CreateNodeClusterCtx(ctx context.Context, appPath string) error {
+	if sdk.ClusterManager != nil {
+		return sdk.ClusterManager.CreateCluster(ctx, appPath, sdk.RPCEndpoint)
 	}
+	return sdk.Transport.CreateNodeCluster(ctx, appPath, sdk.RPCEndpoint)
+}
 
-	fmt.Println(string(output))
-	return nil
+// ClusterMembersCtx lists the current members of appPath's node
+// cluster. It requires a ClusterManager (see WithClusterManager).
+func (sdk *MetaNodeSDK) ClusterMembersCtx(ctx context.Context, appPath string) ([]string, error) {
+	if sdk.ClusterManager == nil {
+		return nil, fmt.Errorf("cluster membership requires a ClusterManager (see WithClusterManager)")
+	}
+	return sdk.ClusterManager.Members(ctx, appPath)
 }
 
-// CheckStatus checks the status of a MetaNode application
-func (sdk *MetaNodeSDK) CheckStatus(appPath string) error {
-	cmd := exec.Command(sdk.CLIPath, "status", appPath)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error checking status: %v, output: %s", err, string(output))
+// ClusterHealthCtx reports per-member health for appPath's node
+// cluster. It requires a ClusterManager (see WithClusterManager).
+func (sdk *MetaNodeSDK) ClusterHealthCtx(ctx context.Context, appPath string) (map[string]string, error) {
+	if sdk.ClusterManager == nil {
+		return nil, fmt.Errorf("cluster health requires a ClusterManager (see WithClusterManager)")
 	}
+	return sdk.ClusterManager.Health(ctx, appPath)
+}
 
-	fmt.Println(string(output))
-	return nil
+// CheckStatus checks the status of a MetaNode application
+func (sdk *MetaNodeSDK) CheckStatus(appPath string) error {
+	return sdk.CheckStatusCtx(context.Background(), appPath)
 }
 
-// TestTestnetConnection tests the connection to the testnet
-func (sdk *MetaNodeSDK) TestTestnetConnection() error {
-	cmd := exec.Command(sdk.CLIPath, "testnet", "--test", "--rpc", sdk.RPCEndpoint)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("error testing testnet connection: %v, output: %s", err, string(output))
-	}
+// CheckStatusCtx is the context-aware variant of CheckStatus.
+func (sdk *MetaNodeSDK) CheckStatusCtx(ctx context.Context, appPath string) error {
+	return sdk.Transport.CheckStatus(ctx, appPath)
+}
 
-	fmt.Println(string(output))
-	return nil
+// Status is CheckStatusCtx under the name the Common interface expects.
+func (sdk *MetaNodeSDK) Status(ctx context.Context, appPath string) error {
+	return sdk.CheckStatusCtx(ctx, appPath)
 }
 
-// Helper function to find the CLI path
-func findCLI() (string, error) {
-	// Check if CLI is in PATH
-	path, err := exec.LookPath("metanode-cli")
-	if err == nil {
-		return path, nil
-	}
+// Version reports the SDK version, satisfying the Common interface.
+func (sdk *MetaNodeSDK) Version(ctx context.Context) (string, error) {
+	return Version, nil
+}
 
-	// Check in common locations
-	locations := []string{
-		filepath.Join(os.Getenv("HOME"), "bin", "metanode-cli"),
-		filepath.Join(os.Getenv("HOME"), "metanode-deployment", "bin", "metanode-cli"),
-		"/usr/local/bin/metanode-cli",
-	}
+// AuthVerify verifies token against sdk.Authenticator and returns the
+// permission scopes it grants ("read"/"write"/"admin"/"sign"),
+// satisfying the Common interface. It fails closed: with no
+// Authenticator configured (the default), every token is rejected.
+func (sdk *MetaNodeSDK) AuthVerify(ctx context.Context, token string) ([]string, error) {
+	return sdk.Authenticator.Verify(ctx, token)
+}
 
-	for _, loc := range locations {
-		if _, err := os.Stat(loc); err == nil {
-			return loc, nil
-		}
-	}
+// TestTestnetConnection tests the connection to the testnet
+func (sdk *MetaNodeSDK) TestTestnetConnection() error {
+	return sdk.TestTestnetConnectionCtx(context.Background())
+}
 
-	return "", fmt.Errorf("metanode-cli not found")
+// TestTestnetConnectionCtx is the context-aware variant of
+// TestTestnetConnection.
+func (sdk *MetaNodeSDK) TestTestnetConnectionCtx(ctx context.Context) error {
+	return sdk.Transport.TestTestnetConnection(ctx, sdk.RPCEndpoint)
 }
@@ -0,0 +1,252 @@
+package metanode
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/GlobalSushrut/metanode-sdk/go/beacon"
+	"github.com/GlobalSushrut/metanode-sdk/go/internal/rpcpool"
+)
+
+// Wallet signs data on behalf of the SDK user. Implementations may hold
+// keys on disk, in an HSM, or in a remote signer.
+type Wallet interface {
+	Address() string
+	Sign(data []byte) ([]byte, error)
+}
+
+// KeyStore persists named key material for a Wallet.
+type KeyStore interface {
+	Get(name string) ([]byte, error)
+	Put(name string, key []byte) error
+}
+
+// AgreementStore persists and retrieves Agreement records. The default
+// implementation reads/writes the on-disk metanode_agreements layout used
+// by metanode-cli; WithAgreementStore swaps it for Postgres, Badger, etc.
+type AgreementStore interface {
+	Save(appPath string, a *Agreement) error
+	Load(appPath, agreementID string) (*Agreement, error)
+}
+
+// ClusterManager tracks node cluster membership and health for an app.
+type ClusterManager interface {
+	CreateCluster(ctx context.Context, appPath, rpcEndpoint string) error
+	Members(ctx context.Context, appPath string) ([]string, error)
+	Health(ctx context.Context, appPath string) (map[string]string, error)
+}
+
+// Authenticator verifies a bearer token and returns the permission
+// scopes it grants ("read", "write", "admin", "sign"). It backs
+// MetaNodeSDK.AuthVerify and metanode/rpcserver's permission checks.
+type Authenticator interface {
+	Verify(ctx context.Context, token string) ([]string, error)
+}
+
+// Logger is the minimal logging interface the SDK writes diagnostics to.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// MetricsSink receives SDK-observed metrics (call latency, failure
+// counts, etc). Observe is fire-and-forget; implementations must not
+// block the caller.
+type MetricsSink interface {
+	Observe(name string, value float64)
+}
+
+// Option configures a MetaNodeSDK at construction time.
+type Option func(*MetaNodeSDK)
+
+// WithNetwork overrides the default "testnet" network name.
+func WithNetwork(network string) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Network = network }
+}
+
+// WithTransport sets the Transport used to reach a MetaNode node,
+// overriding the default CLITransport/RPCTransport selection.
+func WithTransport(t Transport) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Transport = t }
+}
+
+// WithRPC switches the SDK to a native RPCTransport talking to
+// rpcEndpoint/wsEndpoint instead of shelling out to metanode-cli.
+func WithRPC(rpcEndpoint, wsEndpoint string) Option {
+	return func(sdk *MetaNodeSDK) {
+		sdk.RPCEndpoint = rpcEndpoint
+		sdk.WSEndpoint = wsEndpoint
+		sdk.Transport = NewRPCTransport(rpcEndpoint)
+	}
+}
+
+// WithRPCEndpoints switches the SDK to a health-scored pool of RPC
+// endpoints instead of a single RPCEndpoint, failing over between them
+// on network/5xx errors. It takes precedence over WithRPC/WithTransport
+// regardless of option order. Pair with WithRPCFailoverPolicy to tune
+// scoring; omit it to use rpcpool.DefaultFailoverPolicy.
+func WithRPCEndpoints(endpoints ...string) Option {
+	return func(sdk *MetaNodeSDK) { sdk.rpcPoolEndpoints = endpoints }
+}
+
+// WithRPCFailoverPolicy tunes the health scoring used by
+// WithRPCEndpoints. It has no effect without WithRPCEndpoints.
+func WithRPCFailoverPolicy(policy rpcpool.FailoverPolicy) Option {
+	return func(sdk *MetaNodeSDK) { sdk.rpcPoolPolicy = policy }
+}
+
+// WithBeacon supplies the verifiable randomness beacon used to seed
+// CreateAgreement instead of crypto/rand.
+func WithBeacon(b beacon.Beacon) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Beacon = b }
+}
+
+// WithWallet supplies the Wallet used to sign transactions and
+// agreements.
+func WithWallet(w Wallet) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Wallet = w }
+}
+
+// WithKeyStore supplies the KeyStore backing the SDK's Wallet.
+func WithKeyStore(ks KeyStore) Option {
+	return func(sdk *MetaNodeSDK) { sdk.KeyStore = ks }
+}
+
+// WithAgreementStore replaces the default on-disk AgreementStore.
+func WithAgreementStore(s AgreementStore) Option {
+	return func(sdk *MetaNodeSDK) { sdk.AgreementStore = s }
+}
+
+// WithClusterManager supplies the ClusterManager used by
+// CreateNodeCluster.
+func WithClusterManager(cm ClusterManager) Option {
+	return func(sdk *MetaNodeSDK) { sdk.ClusterManager = cm }
+}
+
+// WithAuthenticator replaces the SDK's default deny-all Authenticator,
+// used by AuthVerify and by metanode/rpcserver to authorize calls.
+func WithAuthenticator(a Authenticator) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Authenticator = a }
+}
+
+// WithLogger overrides the SDK's no-op default Logger.
+func WithLogger(l Logger) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Logger = l }
+}
+
+// WithMetrics overrides the SDK's no-op default MetricsSink.
+func WithMetrics(m MetricsSink) Option {
+	return func(sdk *MetaNodeSDK) { sdk.Metrics = m }
+}
+
+// fileAgreementStore is the default AgreementStore: it mirrors the
+// on-disk <appPath>/metanode_agreements layout metanode-cli writes.
+type fileAgreementStore struct{}
+
+func (fileAgreementStore) Load(appPath, agreementID string) (*Agreement, error) {
+	return GetAgreement(appPath, agreementID)
+}
+
+func (fileAgreementStore) Save(appPath string, a *Agreement) error {
+	return SaveAgreement(appPath, a)
+}
+
+// fileKeyStore is the default KeyStore: it persists each named key as a
+// file under dir (sdk.WalletPath), matching the on-disk wallet layout
+// metanode-cli uses.
+type fileKeyStore struct {
+	dir string
+}
+
+func (ks fileKeyStore) Get(name string) ([]byte, error) {
+	return ioutil.ReadFile(filepath.Join(ks.dir, name))
+}
+
+func (ks fileKeyStore) Put(name string, key []byte) error {
+	if err := os.MkdirAll(ks.dir, 0755); err != nil {
+		return fmt.Errorf("metanode: creating key store directory: %v", err)
+	}
+	return ioutil.WriteFile(filepath.Join(ks.dir, name), key, 0600)
+}
+
+// keystoreWallet is the default Wallet: it signs with an ed25519 keypair
+// persisted in (and lazily generated into) a KeyStore, so the default
+// Wallet and KeyStore are actually wired together rather than being
+// independent pieces of decoration.
+type keystoreWallet struct {
+	ks   KeyStore
+	name string
+}
+
+func (w keystoreWallet) key() (ed25519.PrivateKey, error) {
+	raw, err := w.ks.Get(w.name)
+	if err == nil && len(raw) == ed25519.PrivateKeySize {
+		return ed25519.PrivateKey(raw), nil
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("metanode: generating wallet key: %v", err)
+	}
+	if err := w.ks.Put(w.name, priv); err != nil {
+		return nil, fmt.Errorf("metanode: persisting wallet key: %v", err)
+	}
+	return priv, nil
+}
+
+// Address returns the wallet's ed25519 public key, hex-encoded. It
+// returns "" if the key can't be loaded or generated.
+func (w keystoreWallet) Address() string {
+	priv, err := w.key()
+	if err != nil {
+		return ""
+	}
+	return hex.EncodeToString(priv.Public().(ed25519.PublicKey))
+}
+
+func (w keystoreWallet) Sign(data []byte) ([]byte, error) {
+	priv, err := w.key()
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.Sign(priv, data), nil
+}
+
+// noopLogger discards everything; it is the SDK's default Logger.
+type noopLogger struct{}
+
+func (noopLogger) Printf(format string, args ...interface{}) {}
+
+// noopMetricsSink discards everything; it is the SDK's default
+// MetricsSink.
+type noopMetricsSink struct{}
+
+func (noopMetricsSink) Observe(name string, value float64) {}
+
+// denyAllAuthenticator rejects every token; it is the SDK's default
+// Authenticator so AuthVerify/rpcserver fail closed until a real
+// Authenticator is configured via WithAuthenticator.
+type denyAllAuthenticator struct{}
+
+func (denyAllAuthenticator) Verify(ctx context.Context, token string) ([]string, error) {
+	return nil, fmt.Errorf("metanode: no Authenticator configured (see WithAuthenticator)")
+}
+
+// StaticTokenAuthenticator grants the scopes configured for each known
+// token and rejects unknown ones. It is the simplest Authenticator
+// suitable for a single-operator gateway; production deployments should
+// implement Authenticator against their own identity provider.
+type StaticTokenAuthenticator map[string][]string
+
+func (a StaticTokenAuthenticator) Verify(ctx context.Context, token string) ([]string, error) {
+	scopes, ok := a[token]
+	if !ok {
+		return nil, fmt.Errorf("metanode: unknown token")
+	}
+	return scopes, nil
+}
@@ -0,0 +1,32 @@
+package metanode
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStaticTokenAuthenticatorVerify(t *testing.T) {
+	auth := StaticTokenAuthenticator{
+		"good-token": {"read", "write"},
+	}
+
+	scopes, err := auth.Verify(context.Background(), "good-token")
+	if err != nil {
+		t.Fatalf("Verify(good-token): %v", err)
+	}
+	if len(scopes) != 2 || scopes[0] != "read" || scopes[1] != "write" {
+		t.Fatalf("Verify(good-token) scopes = %v, want [read write]", scopes)
+	}
+
+	if _, err := auth.Verify(context.Background(), "unknown-token"); err == nil {
+		t.Fatal("expected Verify for an unknown token to fail")
+	}
+}
+
+func TestDenyAllAuthenticatorRejectsEveryToken(t *testing.T) {
+	var auth denyAllAuthenticator
+
+	if _, err := auth.Verify(context.Background(), "anything"); err == nil {
+		t.Fatal("expected denyAllAuthenticator to reject every token")
+	}
+}
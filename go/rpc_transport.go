@@ -0,0 +1,185 @@
+package metanode
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/GlobalSushrut/metanode-sdk/go/internal/rpcpool"
+)
+
+// RPCTransport implements Transport by speaking JSON-RPC 2.0 directly to a
+// MetaNode node's RPCEndpoint, skipping the metanode-cli binary entirely.
+// With a single endpoint it calls that endpoint directly; with a Pool it
+// picks the healthiest endpoint per call and fails over on errors.
+type RPCTransport struct {
+	endpoint   string
+	pool       *rpcpool.Pool
+	httpClient *http.Client
+	nextID     uint64
+
+	// Logger receives diagnostics that CheckStatus has nowhere else to
+	// surface, since Transport.CheckStatus returns only an error.
+	// Defaults to a no-op; NewSDK points it at sdk.Logger.
+	Logger Logger
+}
+
+// NewRPCTransport returns a Transport backed by a pooled HTTP client
+// talking JSON-RPC to a single endpoint.
+func NewRPCTransport(endpoint string) *RPCTransport {
+	return &RPCTransport{endpoint: endpoint, httpClient: newHTTPClient(), Logger: noopLogger{}}
+}
+
+// NewRPCTransportPool returns a Transport that distributes JSON-RPC calls
+// across pool, picking the healthiest endpoint per call and failing over
+// on network/5xx errors.
+func NewRPCTransportPool(pool *rpcpool.Pool) *RPCTransport {
+	return &RPCTransport{pool: pool, httpClient: newHTTPClient(), Logger: noopLogger{}}
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:        64,
+			MaxIdleConnsPerHost: 16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      uint64      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+// call issues a single JSON-RPC request and decodes the result into out.
+// When the transport has a Pool, it picks the healthiest endpoint and
+// fails over to the next-best one on error; otherwise it calls the
+// single configured endpoint directly.
+func (t *RPCTransport) call(ctx context.Context, method string, params interface{}, out interface{}) error {
+	if t.pool != nil {
+		return t.pool.Do(ctx, func(ctx context.Context, endpoint string) error {
+			return t.callEndpoint(ctx, endpoint, method, params, out)
+		})
+	}
+	return t.callEndpoint(ctx, t.endpoint, method, params, out)
+}
+
+func (t *RPCTransport) callEndpoint(ctx context.Context, endpoint, method string, params interface{}, out interface{}) error {
+	req := rpcRequest{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&t.nextID, 1),
+		Method:  method,
+		Params:  params,
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("error encoding rpc request: %v", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error building rpc request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error calling %s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("error decoding rpc response: %v", err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+
+	if out == nil || len(rpcResp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rpcResp.Result, out)
+}
+
+func (t *RPCTransport) InitApp(ctx context.Context, appName, network, rpcEndpoint string) error {
+	params := map[string]string{"app_name": appName, "network": network, "rpc_endpoint": rpcEndpoint}
+	return t.call(ctx, "metanode_initApp", params, nil)
+}
+
+func (t *RPCTransport) DeployApp(ctx context.Context, appPath, network, rpcEndpoint string) error {
+	params := map[string]string{"app_path": appPath, "network": network, "rpc_endpoint": rpcEndpoint}
+	return t.call(ctx, "metanode_deployApp", params, nil)
+}
+
+func (t *RPCTransport) CreateAgreement(ctx context.Context, appPath, agreementType string, seed []byte) (*Agreement, error) {
+	params := map[string]string{"app_path": appPath, "agreement_type": agreementType}
+	if len(seed) > 0 {
+		params["seed"] = hex.EncodeToString(seed)
+	}
+	var agreement Agreement
+	if err := t.call(ctx, "metanode_createAgreement", params, &agreement); err != nil {
+		return nil, fmt.Errorf("error creating agreement: %v", err)
+	}
+	return &agreement, nil
+}
+
+func (t *RPCTransport) DeployAgreement(ctx context.Context, appPath, agreementID string) error {
+	params := map[string]string{"app_path": appPath, "agreement_id": agreementID}
+	return t.call(ctx, "metanode_deployAgreement", params, nil)
+}
+
+func (t *RPCTransport) CheckStatus(ctx context.Context, appPath string) error {
+	params := map[string]string{"app_path": appPath}
+	var status map[string]interface{}
+	if err := t.call(ctx, "metanode_status", params, &status); err != nil {
+		return fmt.Errorf("error checking status: %v", err)
+	}
+	t.Logger.Printf("metanode: status for %s: %+v", appPath, status)
+	return nil
+}
+
+func (t *RPCTransport) TestTestnetConnection(ctx context.Context, rpcEndpoint string) error {
+	params := map[string]string{"rpc_endpoint": rpcEndpoint}
+	return t.call(ctx, "metanode_testTestnet", params, nil)
+}
+
+func (t *RPCTransport) CreateNodeCluster(ctx context.Context, appPath, rpcEndpoint string) error {
+	params := map[string]string{"app_path": appPath, "rpc_endpoint": rpcEndpoint}
+	return t.call(ctx, "metanode_createNodeCluster", params, nil)
+}
+
+// Ping performs a lightweight liveness call against endpoint, returning
+// its current block height. rpcpool.Pool.Watch uses it to detect
+// stalled nodes in a multi-endpoint pool.
+func (t *RPCTransport) Ping(ctx context.Context, endpoint string) (uint64, error) {
+	var height uint64
+	err := t.callEndpoint(ctx, endpoint, "metanode_blockNumber", nil, &height)
+	return height, err
+}
@@ -0,0 +1,184 @@
+// Package rpcserver reflectively serves a metanode.Common/Full/Cluster
+// implementation over JSON-RPC 2.0, so a MetaNode gateway process can
+// expose the Go SDK to remote users and CLIs. metanode-cli itself can
+// become a thin JSON-RPC client of the same interfaces the Go SDK
+// exposes, following how Lotus's API split lets its CLI and remote
+// clients share one interface surface.
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	metanode "github.com/GlobalSushrut/metanode-sdk/go"
+)
+
+// Scope is a permission scope granted by a metanode.Authenticator.
+type Scope string
+
+// Scopes recognized by the default method table, from least to most
+// privileged.
+const (
+	ScopeRead  Scope = "read"
+	ScopeWrite Scope = "write"
+	ScopeSign  Scope = "sign"
+	ScopeAdmin Scope = "admin"
+)
+
+// methodScopes maps each "Interface.Method" served below to the scope
+// required to call it. A method missing from this table cannot be
+// called, regardless of scopes held: the server fails closed.
+var methodScopes = map[string]Scope{
+	"Common.Version":                ScopeRead,
+	"Common.AuthVerify":             ScopeRead,
+	"Common.Status":                 ScopeRead,
+	"Full.InitAppCtx":               ScopeWrite,
+	"Full.DeployAppCtx":             ScopeWrite,
+	"Full.CreateAgreementCtx":       ScopeWrite,
+	"Full.DeployAgreementCtx":       ScopeSign,
+	"Full.TestTestnetConnectionCtx": ScopeRead,
+	"Cluster.CreateNodeClusterCtx":  ScopeAdmin,
+	"Cluster.ClusterMembersCtx":     ScopeRead,
+	"Cluster.ClusterHealthCtx":      ScopeRead,
+}
+
+// Server reflectively serves a metanode.Common/Full/Cluster
+// implementation (in practice a *metanode.MetaNodeSDK) over JSON-RPC
+// 2.0, authorizing each call with auth.
+type Server struct {
+	impl interface{}
+	auth metanode.Authenticator
+}
+
+// New returns a Server exposing svc's Common/Full/Cluster methods,
+// authorizing each call against auth.
+func New(svc interface{}, auth metanode.Authenticator) *Server {
+	return &Server{impl: svc, auth: auth}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler, serving one JSON-RPC 2.0 request
+// per POST body.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, nil, -32700, fmt.Sprintf("parse error: %v", err))
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	result, err := s.dispatch(r.Context(), token, req.Method, req.Params)
+	if err != nil {
+		s.writeError(w, req.ID, -32000, err.Error())
+		return
+	}
+
+	json.NewEncoder(w).Encode(response{JSONRPC: "2.0", ID: req.ID, Result: result})
+}
+
+func (s *Server) writeError(w http.ResponseWriter, id json.RawMessage, code int, msg string) {
+	json.NewEncoder(w).Encode(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: msg}})
+}
+
+func (s *Server) dispatch(ctx context.Context, token, method string, rawParams json.RawMessage) (interface{}, error) {
+	scope, ok := methodScopes[method]
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+
+	granted, err := s.auth.Verify(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("auth: %v", err)
+	}
+	if !hasScope(granted, scope) {
+		return nil, fmt.Errorf("token lacks %q scope required for %q", scope, method)
+	}
+
+	return callMethod(ctx, s.impl, method, rawParams)
+}
+
+func hasScope(granted []string, want Scope) bool {
+	for _, g := range granted {
+		if g == string(want) || g == string(ScopeAdmin) {
+			return true
+		}
+	}
+	return false
+}
+
+// callMethod invokes impl's <Method> (the part of "Interface.Method"
+// after the dot) via reflection, decoding rawParams positionally into
+// its non-context arguments.
+func callMethod(ctx context.Context, impl interface{}, method string, rawParams json.RawMessage) (interface{}, error) {
+	parts := strings.SplitN(method, ".", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("method must be \"Interface.Method\", got %q", method)
+	}
+
+	fn := reflect.ValueOf(impl).MethodByName(parts[1])
+	if !fn.IsValid() {
+		return nil, fmt.Errorf("%T does not implement %q", impl, parts[1])
+	}
+
+	var params []json.RawMessage
+	if len(rawParams) > 0 {
+		if err := json.Unmarshal(rawParams, &params); err != nil {
+			return nil, fmt.Errorf("decoding params: %v", err)
+		}
+	}
+
+	fnType := fn.Type()
+	args := make([]reflect.Value, fnType.NumIn())
+	args[0] = reflect.ValueOf(ctx)
+	for i := 1; i < fnType.NumIn(); i++ {
+		argPtr := reflect.New(fnType.In(i))
+		if i-1 < len(params) {
+			if err := json.Unmarshal(params[i-1], argPtr.Interface()); err != nil {
+				return nil, fmt.Errorf("decoding param %d: %v", i-1, err)
+			}
+		}
+		args[i] = argPtr.Elem()
+	}
+
+	return unpackResult(fn.Call(args))
+}
+
+// unpackResult splits a served method's return values into (value,
+// error): every served method's last return value is an error.
+func unpackResult(out []reflect.Value) (interface{}, error) {
+	if len(out) == 0 {
+		return nil, nil
+	}
+
+	if errVal, ok := out[len(out)-1].Interface().(error); ok && errVal != nil {
+		return nil, errVal
+	}
+	if len(out) == 1 {
+		return nil, nil
+	}
+	return out[0].Interface(), nil
+}
@@ -0,0 +1,90 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// fakeCommon is a minimal stand-in for a metanode.Common implementation,
+// exercising callMethod's reflection dispatch without needing a real
+// MetaNodeSDK.
+type fakeCommon struct{}
+
+func (fakeCommon) Version(ctx context.Context) (string, error) {
+	return "v-test", nil
+}
+
+func (fakeCommon) AuthVerify(ctx context.Context, token string) ([]string, error) {
+	if token == "" {
+		return nil, fmt.Errorf("empty token")
+	}
+	return []string{"read"}, nil
+}
+
+func (fakeCommon) Status(ctx context.Context, appPath string) error {
+	if appPath == "missing" {
+		return fmt.Errorf("no such app: %s", appPath)
+	}
+	return nil
+}
+
+func rawParams(t *testing.T, v ...interface{}) json.RawMessage {
+	t.Helper()
+	parts := make([]json.RawMessage, len(v))
+	for i, p := range v {
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("marshaling param %d: %v", i, err)
+		}
+		parts[i] = b
+	}
+	b, err := json.Marshal(parts)
+	if err != nil {
+		t.Fatalf("marshaling params: %v", err)
+	}
+	return b
+}
+
+func TestCallMethodDecodesParamsAndReturnsResult(t *testing.T) {
+	result, err := callMethod(context.Background(), fakeCommon{}, "Common.AuthVerify", rawParams(t, "a-token"))
+	if err != nil {
+		t.Fatalf("callMethod: %v", err)
+	}
+	scopes, ok := result.([]string)
+	if !ok || len(scopes) != 1 || scopes[0] != "read" {
+		t.Fatalf("callMethod result = %#v, want []string{\"read\"}", result)
+	}
+}
+
+func TestCallMethodSurfacesMethodError(t *testing.T) {
+	_, err := callMethod(context.Background(), fakeCommon{}, "Common.Status", rawParams(t, "missing"))
+	if err == nil {
+		t.Fatal("expected callMethod to surface the method's returned error")
+	}
+}
+
+func TestCallMethodRejectsUnknownMethod(t *testing.T) {
+	if _, err := callMethod(context.Background(), fakeCommon{}, "Common.DoesNotExist", nil); err == nil {
+		t.Fatal("expected callMethod to reject a method the impl does not implement")
+	}
+}
+
+func TestCallMethodRejectsMalformedMethodName(t *testing.T) {
+	if _, err := callMethod(context.Background(), fakeCommon{}, "Version", nil); err == nil {
+		t.Fatal("expected callMethod to reject a method name without an \"Interface.\" prefix")
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	if !hasScope([]string{"read"}, ScopeRead) {
+		t.Fatal("hasScope should grant an exact scope match")
+	}
+	if !hasScope([]string{"admin"}, ScopeWrite) {
+		t.Fatal("hasScope should grant any scope when the token holds admin")
+	}
+	if hasScope([]string{"read"}, ScopeWrite) {
+		t.Fatal("hasScope should not grant a scope the token doesn't hold")
+	}
+}
@@ -0,0 +1,22 @@
+package metanode
+
+import "context"
+
+// Transport abstracts how the SDK talks to a MetaNode node. CLITransport
+// shells out to the metanode-cli binary, while RPCTransport speaks
+// JSON-RPC directly to RPCEndpoint/WSEndpoint. NewSDK defaults to
+// CLITransport for backwards compatibility; NewRPCSDK opts into the
+// native client.
+type Transport interface {
+	InitApp(ctx context.Context, appName, network, rpcEndpoint string) error
+	DeployApp(ctx context.Context, appPath, network, rpcEndpoint string) error
+	// CreateAgreement creates an agreement. If seed is non-empty, the
+	// agreement's ID / initial validator set / cluster shard assignment
+	// should be derived from it (typically a beacon.BeaconEntry.Data)
+	// instead of local randomness.
+	CreateAgreement(ctx context.Context, appPath, agreementType string, seed []byte) (*Agreement, error)
+	DeployAgreement(ctx context.Context, appPath, agreementID string) error
+	CheckStatus(ctx context.Context, appPath string) error
+	TestTestnetConnection(ctx context.Context, rpcEndpoint string) error
+	CreateNodeCluster(ctx context.Context, appPath, rpcEndpoint string) error
+}
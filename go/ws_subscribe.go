@@ -0,0 +1,159 @@
+package metanode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
+
+// BlockEvent describes a new block notification received over WSEndpoint.
+type BlockEvent struct {
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+// AgreementEvent describes an agreement lifecycle notification.
+type AgreementEvent struct {
+	AgreementID string `json:"agreement_id"`
+	Status      string `json:"status"`
+}
+
+// TxEvent describes a transaction notification.
+type TxEvent struct {
+	TxHash string `json:"tx_hash"`
+	Status string `json:"status"`
+}
+
+type wsSubscribeRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []string `json:"params"`
+}
+
+type wsNotification struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// subscribe opens a WS connection to endpoint, subscribes to channel, and
+// decodes each notification payload into out, sending a copy on the
+// returned channel until ctx is cancelled or the connection drops.
+func subscribe(ctx context.Context, endpoint, channel string, decode func(json.RawMessage) (interface{}, error)) (<-chan interface{}, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %v", endpoint, err)
+	}
+
+	req := wsSubscribeRequest{JSONRPC: "2.0", ID: 1, Method: "metanode_subscribe", Params: []string{channel}}
+	if err := conn.WriteJSON(req); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error subscribing to %s: %v", channel, err)
+	}
+
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		defer conn.Close()
+
+		for {
+			var notif wsNotification
+			if err := conn.ReadJSON(&notif); err != nil {
+				return
+			}
+
+			event, err := decode(notif.Params)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return out, nil
+}
+
+// SubscribeBlocks streams newly produced blocks until ctx is cancelled.
+func (sdk *MetaNodeSDK) SubscribeBlocks(ctx context.Context) (<-chan BlockEvent, error) {
+	raw, err := subscribe(ctx, sdk.WSEndpoint, "blocks", func(p json.RawMessage) (interface{}, error) {
+		var e BlockEvent
+		return &e, json.Unmarshal(p, &e)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan BlockEvent)
+	go func() {
+		defer close(out)
+		for e := range raw {
+			select {
+			case out <- *e.(*BlockEvent):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeAgreements streams agreement lifecycle updates until ctx is
+// cancelled.
+func (sdk *MetaNodeSDK) SubscribeAgreements(ctx context.Context) (<-chan AgreementEvent, error) {
+	raw, err := subscribe(ctx, sdk.WSEndpoint, "agreements", func(p json.RawMessage) (interface{}, error) {
+		var e AgreementEvent
+		return &e, json.Unmarshal(p, &e)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan AgreementEvent)
+	go func() {
+		defer close(out)
+		for e := range raw {
+			select {
+			case out <- *e.(*AgreementEvent):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// SubscribeTxs streams transaction status updates until ctx is cancelled.
+func (sdk *MetaNodeSDK) SubscribeTxs(ctx context.Context) (<-chan TxEvent, error) {
+	raw, err := subscribe(ctx, sdk.WSEndpoint, "txs", func(p json.RawMessage) (interface{}, error) {
+		var e TxEvent
+		return &e, json.Unmarshal(p, &e)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan TxEvent)
+	go func() {
+		defer close(out)
+		for e := range raw {
+			select {
+			case out <- *e.(*TxEvent):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}